@@ -18,19 +18,33 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
+	tunnelsv1alpha1 "github.com/cookytime/cloudflare-operator/api/v1alpha1"
+	"github.com/cookytime/cloudflare-operator/dns"
 	yaml "gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
@@ -50,17 +64,90 @@ const (
 	tunnelFinalizerAnnotation = "tunnels.networking.cfargotunnel.com/finalizer"
 	tunnelDomainAnnotation    = "tunnels.networking.cfargotunnel.com/domain"
 	configmapKey              = "config.yaml"
+
+	// defaultControllerClass is the IngressClass controller value this operator
+	// reconciles by default. Deployments running multiple tunnel operators in the
+	// same cluster can override it with the --controller-class flag so each
+	// operator only picks up the IngressClasses assigned to it.
+	defaultControllerClass = "tunnels.networking.cfargotunnel.com/controller"
+
+	// Parameters on the IngressClass are expected to point at the Tunnel CRD in
+	// the same way the annotations above do.
+	ingressClassParamCRDKind = "Tunnel"
+
+	// originRequestAnnotationPrefix namespaces per-field overrides for
+	// cloudflared's originRequest config, e.g.
+	// `tunnels.networking.cfargotunnel.com/origin-noTLSVerify: "true"`.
+	originRequestAnnotationPrefix = "tunnels.networking.cfargotunnel.com/origin-"
+	// caPoolMountPath is where the cloudflared Deployment mounts CA secrets
+	// referenced by the origin-caPoolSecret annotation, keyed by Secret name.
+	caPoolMountPath = "/etc/cloudflared/certs"
+
+	// configMapContentHashAnnotation records a digest of the config.yaml this
+	// controller last wrote, so the ConfigMap watch can tell its own writes
+	// apart from out-of-band drift and avoid requeueing Ingresses forever.
+	configMapContentHashAnnotation = "tunnels.networking.cfargotunnel.com/content-hash"
+
+	// configMapOwnersAnnotation records which Ingress wrote each (hostname,
+	// path) tunnel rule, so a second Ingress claiming the same FQDN is
+	// detected as a conflict instead of silently clobbering the first.
+	configMapOwnersAnnotation = "tunnels.networking.cfargotunnel.com/rule-owners"
+
+	// manageDNSAnnotation opts an Ingress into having this controller upsert
+	// (and later delete) the DNS record for its FQDN(s), instead of that
+	// being done out of band.
+	manageDNSAnnotation = "tunnels.networking.cfargotunnel.com/manage-dns"
+	// dnsRecordTypeAnnotation picks the DNS record type to manage; defaults to
+	// CNAME pointed at the tunnel, or A/AAAA when pinned to an edge IP.
+	dnsRecordTypeAnnotation = "tunnels.networking.cfargotunnel.com/dns-record-type"
+	// dnsTargetAnnotation is the edge IP to use as the record's content when
+	// dnsRecordTypeAnnotation is A or AAAA.
+	dnsTargetAnnotation = "tunnels.networking.cfargotunnel.com/dns-target"
+
+	// cloudflareAPITokenSecretKey is the key read from the Secret referenced
+	// on the Tunnel CR to authenticate DNS management calls.
+	cloudflareAPITokenSecretKey = "CLOUDFLARE_API_TOKEN"
 )
 
 // IngressReconciler reconciles a Ingress object
 type IngressReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// ControllerClass is the IngressClass `.spec.controller` value this
+	// reconciler is responsible for. Ingresses that reference an IngressClass
+	// with a different controller value are ignored. Defaults to
+	// defaultControllerClass when unset.
+	ControllerClass string
+
+	// Recorder emits Kubernetes Events on the Ingresses this reconciler
+	// configures. Set by main to mgr.GetEventRecorderFor(...); nil is
+	// tolerated so existing tests that don't wire one up keep working.
+	Recorder record.EventRecorder
+
+	// DNSClient, when set, is used for every Ingress opted into DNS
+	// management instead of building one from the Tunnel's credentials
+	// Secret. Tests inject a fake here.
+	DNSClient dns.Client
+	// DNSClientFactory builds a dns.Client from a Tunnel's Cloudflare API
+	// token. Defaults to dns.NewCloudflareClient; only used when DNSClient is
+	// unset.
+	DNSClientFactory func(apiToken string) (dns.Client, error)
+	// ManageDNS forces DNS management on for every Ingress this operator
+	// handles, equivalent to every Ingress carrying manageDNSAnnotation.
+	ManageDNS bool
 }
 
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/finalizers,verbs=update
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=tunnels.networking.cfargotunnel.com,resources=tunnels,verbs=get;list;watch
+//+kubebuilder:rbac:groups=tunnels.networking.cfargotunnel.com,resources=tunnels/status,verbs=get;update;patch
 
 func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := ctrllog.FromContext(ctx)
@@ -80,12 +167,15 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
-	// Read Ingress annotations. If both annotations are not set, return without doing anything
-	tunnelName, okName := ingress.Annotations[tunnelNameAnnotation]
-	tunnelId, okId := ingress.Annotations[tunnelIdAnnotation]
+	// Resolve tunnel selection, preferring the IngressClass' parameters and
+	// falling back to the legacy per-Ingress annotations when the IngressClass
+	// doesn't set them (or isn't used at all).
+	tunnelCRD, okCRD, tunnelName, okName, tunnelId, okId, tunnelNS, okNS, err := r.resolveTunnelParams(ctx, log, ingress)
+	if err != nil {
+		log.Error(err, "unable to resolve tunnel selection for Ingress")
+		return ctrl.Result{}, err
+	}
 	fqdn := ingress.Annotations[fqdnAnnotation]
-	tunnelNS, okNS := ingress.Annotations[tunnelNSAnnotation]
-	tunnelCRD, okCRD := ingress.Annotations[tunnelCRDAnnotation]
 
 	if !(okCRD || okName || okId) {
 		// If an ingress with annotation is edited to remove just annotations, cleanup wont happen.
@@ -105,29 +195,9 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	// listOpts to search for ConfigMap. Set labels, and namespace restriction if
-	listOpts := []client.ListOption{}
-	labels := map[string]string{}
-	if okId {
-		labels[tunnelIdAnnotation] = tunnelId
-	}
-	if okName {
-		labels[tunnelNameAnnotation] = tunnelName
-	}
-	if okCRD {
-		labels[tunnelCRDAnnotation] = tunnelCRD
-	}
-
-	if tunnelNS == "true" || !okNS {
-		labels[tunnelNSAnnotation] = ingress.Namespace
-		listOpts = append(listOpts, client.InNamespace(ingress.Namespace))
-	} else if okNS && tunnelNS != "false" {
-		labels[tunnelNSAnnotation] = tunnelNS
-		listOpts = append(listOpts, client.InNamespace(tunnelNS))
-	} // else, no filter on namespace, pick the 1st one
+	labels, listOpts := tunnelSelector(ingress, tunnelCRD, okCRD, tunnelName, okName, tunnelId, okId, tunnelNS, okNS)
 
-	listOpts = append(listOpts, client.MatchingLabels(labels))
-
-	log.Info("setting tunnel", "listOpts", listOpts)
+	log.Info("setting tunnel", "labels", labels, "listOpts", listOpts)
 
 	// Check if Ingress is marked for deletion
 	if ingress.GetDeletionTimestamp() != nil {
@@ -166,6 +236,101 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	return ctrl.Result{}, nil
 }
 
+// resolveTunnelParams determines which Tunnel CRD/ID/Name/Namespace an Ingress
+// should be routed through. IngressClass parameters take precedence over the
+// per-Ingress annotations, which remain supported for backward compatibility
+// and for Ingresses that don't set an IngressClassName at all.
+func (r *IngressReconciler) resolveTunnelParams(ctx context.Context, log logr.Logger, ingress *networkingv1.Ingress) (tunnelCRD string, okCRD bool, tunnelName string, okName bool, tunnelId string, okId bool, tunnelNS string, okNS bool, err error) {
+	tunnelName, okName = ingress.Annotations[tunnelNameAnnotation]
+	tunnelId, okId = ingress.Annotations[tunnelIdAnnotation]
+	tunnelNS, okNS = ingress.Annotations[tunnelNSAnnotation]
+	tunnelCRD, okCRD = ingress.Annotations[tunnelCRDAnnotation]
+
+	if ingress.Spec.IngressClassName == nil {
+		return
+	}
+
+	ingressClass := &networkingv1.IngressClass{}
+	if getErr := r.Get(ctx, client.ObjectKey{Name: *ingress.Spec.IngressClassName}, ingressClass); getErr != nil {
+		if apierrors.IsNotFound(getErr) {
+			log.Info("IngressClass referenced by Ingress not found, falling back to annotations", "ingressClass", *ingress.Spec.IngressClassName)
+			return
+		}
+		err = getErr
+		return
+	}
+
+	controllerClass := r.ControllerClass
+	if controllerClass == "" {
+		controllerClass = defaultControllerClass
+	}
+	if ingressClass.Spec.Controller != controllerClass {
+		// Not ours; this Ingress explicitly opted into another operator's
+		// class, so discard any legacy annotations too rather than letting
+		// them make us claim it anyway. Otherwise an Ingress carrying stale
+		// annotations from a previous operator would get reconciled by both,
+		// including shared-state writes like the finalizer and ConfigMap/
+		// Tunnel status that belong solely to the class it actually selected.
+		return "", false, "", false, "", false, "", false, nil
+	}
+
+	params := ingressClass.Spec.Parameters
+	if params == nil || params.Kind != ingressClassParamCRDKind {
+		return
+	}
+
+	if params.Name != "" {
+		tunnelCRD, okCRD = params.Name, true
+	}
+	if params.Namespace != nil && *params.Namespace != "" {
+		tunnelNS, okNS = *params.Namespace, true
+	}
+	return
+}
+
+// tunnelSelector computes the ConfigMap label selector and namespace scoping
+// an Ingress's resolved tunnel selection points at. It is shared between
+// Reconcile and the ConfigMap/Tunnel watch mapping functions so both agree on
+// which ConfigMap an Ingress is pointed at.
+func tunnelSelector(ingress *networkingv1.Ingress, tunnelCRD string, okCRD bool, tunnelName string, okName bool, tunnelId string, okId bool, tunnelNS string, okNS bool) (map[string]string, []client.ListOption) {
+	labels := map[string]string{}
+	if okId {
+		labels[tunnelIdAnnotation] = tunnelId
+	}
+	if okName {
+		labels[tunnelNameAnnotation] = tunnelName
+	}
+	if okCRD {
+		labels[tunnelCRDAnnotation] = tunnelCRD
+	}
+
+	listOpts := []client.ListOption{}
+	if namespace, restricted := resolveTunnelNamespace(ingress, tunnelNS, okNS); restricted {
+		labels[tunnelNSAnnotation] = namespace
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	} // else, no filter on namespace, pick the 1st one
+
+	listOpts = append(listOpts, client.MatchingLabels(labels))
+	return labels, listOpts
+}
+
+// resolveTunnelNamespace applies the tunnelNSAnnotation's three-way meaning
+// ("true" or unset => scope to the Ingress' own namespace, an explicit
+// namespace => scope to that namespace, "false" => no namespace restriction,
+// pick the first match found) to a namespace to restrict to. It is shared by
+// tunnelSelector and findIngressesForTunnel so both agree on which namespace,
+// if any, an Ingress's tunnel selection is scoped to.
+func resolveTunnelNamespace(ingress *networkingv1.Ingress, tunnelNS string, okNS bool) (namespace string, restricted bool) {
+	switch {
+	case tunnelNS == "true" || !okNS:
+		return ingress.Namespace, true
+	case okNS && tunnelNS != "false":
+		return tunnelNS, true
+	default:
+		return "", false
+	}
+}
+
 func (r *IngressReconciler) getConfigMapConfiguration(ctx context.Context, log logr.Logger, listOpts []client.ListOption) (corev1.ConfigMap, Configuration, error) {
 	// Fetch ConfigMap from API
 	configMapList := &corev1.ConfigMapList{}
@@ -206,9 +371,61 @@ func (r *IngressReconciler) setConfigMapConfiguration(ctx context.Context, log l
 		return err
 	}
 	configmap.Data[configmapKey] = configStr
+	// Stamp the content hash we're writing so the ConfigMap watch can recognize
+	// and skip the reconcile this update itself triggers.
+	if configmap.Annotations == nil {
+		configmap.Annotations = map[string]string{}
+	}
+	configmap.Annotations[configMapContentHashAnnotation] = contentHash(configStr)
 	return r.Update(ctx, &configmap)
 }
 
+// contentHash returns a short, stable digest of a ConfigMap's config.yaml
+// contents, used to detect reconciles caused by our own writes.
+func contentHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadRuleOwners reads the (hostname, path) -> "namespace/name" map recording
+// which Ingress last wrote each tunnel ingress rule, used to detect when two
+// Ingresses claim the same FQDN (or FQDN+path).
+func loadRuleOwners(configmap corev1.ConfigMap) map[hostPathKey]string {
+	owners := map[hostPathKey]string{}
+	raw, ok := configmap.Annotations[configMapOwnersAnnotation]
+	if !ok {
+		return owners
+	}
+	var flat map[string]string
+	if err := json.Unmarshal([]byte(raw), &flat); err != nil {
+		return owners
+	}
+	for k, v := range flat {
+		hostname, path, found := strings.Cut(k, "\x00")
+		if !found {
+			continue
+		}
+		owners[hostPathKey{hostname, path}] = v
+	}
+	return owners
+}
+
+// saveRuleOwners serializes the rule ownership map back onto the ConfigMap.
+func saveRuleOwners(configmap *corev1.ConfigMap, owners map[hostPathKey]string) {
+	flat := make(map[string]string, len(owners))
+	for k, v := range owners {
+		flat[k.hostname+"\x00"+k.path] = v
+	}
+	raw, err := json.Marshal(flat)
+	if err != nil {
+		return
+	}
+	if configmap.Annotations == nil {
+		configmap.Annotations = map[string]string{}
+	}
+	configmap.Annotations[configMapOwnersAnnotation] = string(raw)
+}
+
 func (r *IngressReconciler) configureCloudflare(log logr.Logger, ctx context.Context, ingress *networkingv1.Ingress, fqdn string, listOpts []client.ListOption, cleanup bool) error {
 	var config Configuration
 	var configmap corev1.ConfigMap
@@ -216,52 +433,123 @@ func (r *IngressReconciler) configureCloudflare(log logr.Logger, ctx context.Con
 
 	if configmap, config, err = r.getConfigMapConfiguration(ctx, log, listOpts); err != nil {
 		log.Error(err, "unable to get ConfigMap")
+		r.event(ingress, corev1.EventTypeWarning, "NoTunnelFound", "unable to find a ConfigMap for the selected tunnel: %v", err)
 		return err
 	}
 	tunnelDomain := configmap.Labels[tunnelDomainAnnotation]
+	ingressRef := fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)
+	owners := loadRuleOwners(configmap)
+
+	// owned tracks the (hostname, path) entries this Ingress wants to own, so
+	// cleanup only drops the rules it actually added and leaves sibling paths
+	// on the same host alone.
+	owned := map[hostPathKey]bool{}
+	var fqdns []string
+	// ownedFqdns collects only the hostnames this Ingress actually owns (won
+	// any conflict on) this reconcile, so DNS sync never points a record at a
+	// host whose tunnel rule write was refused.
+	ownedFqdns := map[string]bool{}
+	conflict := false
 
-	var finalIngress []UnvalidatedIngressRule
-	if cleanup {
-		finalIngress = make([]UnvalidatedIngressRule, 0, len(config.Ingress))
-	}
 	// Loop through the Ingress rules
 	for _, rule := range ingress.Spec.Rules {
 		ingressSpecHost := rule.Host
 
-		// Generate fqdn string from Ingress Spec if not provided
-		if fqdn == "" {
+		// Generate fqdn string from Ingress Spec if not provided. Resolved
+		// per rule, not hoisted into the outer fqdn parameter, since an
+		// Ingress with more than one host must not have every host collapse
+		// onto the first one's derived FQDN.
+		ruleFqdn := fqdn
+		if ruleFqdn == "" {
 			ingressHost := strings.Split(ingressSpecHost, ".")[0]
-			fqdn = fmt.Sprintf("%s.%s", ingressHost, tunnelDomain)
+			ruleFqdn = fmt.Sprintf("%s.%s", ingressHost, tunnelDomain)
 			log.Info("using default domain value", "domain", tunnelDomain)
 		}
-		log.Info("setting fqdn", "fqdn", fqdn)
+		log.Info("setting fqdn", "fqdn", ruleFqdn)
+		fqdns = append(fqdns, ruleFqdn)
+
+		origin := r.parseOriginRequestAnnotations(ingress, ingressSpecHost)
+
+		if rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+			// No paths on this rule; keep routing the whole host as before.
+			key := hostPathKey{ruleFqdn, ""}
+			switch {
+			case cleanup:
+				owned[key] = true
+				if owners[key] == "" || owners[key] == ingressRef {
+					ownedFqdns[ruleFqdn] = true
+				}
+			case owners[key] != "" && owners[key] != ingressRef:
+				conflict = true
+				log.Info("refusing to overwrite tunnel rule owned by another Ingress", "hostname", ruleFqdn, "owner", owners[key])
+				r.event(ingress, corev1.EventTypeWarning, "HostnameConflict", "%s is already routed by Ingress %s; refusing to overwrite it", ruleFqdn, owners[key])
+				r.notifyOwner(ctx, log, owners[key], corev1.EventTypeWarning, "HostnameConflict", "%s is also claimed by Ingress %s; keeping this Ingress's rule", ruleFqdn, ingressRef)
+			default:
+				owners[key] = ingressRef
+				r.upsertIngressRule(log, &config, ruleFqdn, "", ingressSpecHost, origin, false)
+				ownedFqdns[ruleFqdn] = true
+			}
+		} else {
+			// More specific paths must come before less specific ones, since
+			// cloudflared evaluates ingress rules top-down.
+			for _, path := range sortPathsBySpecificity(rule.HTTP.Paths) {
+				cfPath := cloudflarePath(path)
+				key := hostPathKey{ruleFqdn, cfPath}
+
+				if cleanup {
+					owned[key] = true
+					if owners[key] == "" || owners[key] == ingressRef {
+						ownedFqdns[ruleFqdn] = true
+					}
+					continue
+				}
+
+				if owner, taken := owners[key]; taken && owner != ingressRef {
+					conflict = true
+					log.Info("refusing to overwrite tunnel rule owned by another Ingress", "hostname", ruleFqdn, "path", cfPath, "owner", owner)
+					r.event(ingress, corev1.EventTypeWarning, "HostnameConflict", "%s%s is already routed by Ingress %s; refusing to overwrite it", ruleFqdn, cfPath, owner)
+					r.notifyOwner(ctx, log, owner, corev1.EventTypeWarning, "HostnameConflict", "%s%s is also claimed by Ingress %s; keeping this Ingress's rule", ruleFqdn, cfPath, ingressRef)
+					continue
+				}
 
-		// Find if the host already exists in config. If so, modify
-		found := false
-		for i, v := range config.Ingress {
-			if cleanup {
-				if v.Hostname != fqdn {
-					finalIngress = append(finalIngress, v)
+				service, err := r.resolveBackendService(ctx, ingress.Namespace, path.Backend.Service)
+				if err != nil {
+					log.Error(err, "unable to resolve backend Service for Ingress path", "path", path.Path)
+					return err
 				}
-			} else if v.Hostname == fqdn {
-				log.Info("found existing ingress for host, modifying the service", "service", ingressSpecHost)
-				config.Ingress[i].Service = ingressSpecHost
-				found = true
-				break
+				owners[key] = ingressRef
+				r.upsertIngressRule(log, &config, ruleFqdn, cfPath, service, origin, false)
+				ownedFqdns[ruleFqdn] = true
 			}
 		}
 
-		// Else add a new entry
-		if !cleanup && !found {
-			log.Info("adding ingress for host to point to service", "service", ingressSpecHost)
-			config.Ingress = append(config.Ingress, UnvalidatedIngressRule{
-				Hostname: fqdn,
-				Service:  ingressSpecHost,
-			})
+		// Rules for a host can accumulate across separate reconciles (e.g. a
+		// more specific path added later than an existing catch-all), so
+		// re-sort this host's entries every time rather than relying on
+		// sortPathsBySpecificity alone, which only orders the current batch.
+		if !cleanup {
+			resortHostRules(&config, ruleFqdn)
 		}
 	}
 
 	if cleanup {
+		finalIngress := make([]UnvalidatedIngressRule, 0, len(config.Ingress))
+		for _, v := range config.Ingress {
+			key := hostPathKey{v.Hostname, v.Path}
+			// Only drop rules this Ingress actually owns; a rule it lost a
+			// conflict on, or that belongs to a sibling path, stays put. A
+			// rule with no recorded owner predates the ownership tracking
+			// added in chunk0-5: since this Ingress's spec still references
+			// it (owned[key] is only set from the Ingress' own rules above),
+			// treat it as this Ingress's own rule rather than leaking it
+			// forever because it never went through one more non-cleanup
+			// reconcile before being deleted.
+			if owned[key] && (owners[key] == ingressRef || owners[key] == "") {
+				delete(owners, key)
+				continue
+			}
+			finalIngress = append(finalIngress, v)
+		}
 		if len(finalIngress) > 0 {
 			config.Ingress = finalIngress
 		} else {
@@ -269,12 +557,597 @@ func (r *IngressReconciler) configureCloudflare(log logr.Logger, ctx context.Con
 			log.Info("nothing left, setting config to nil")
 		}
 	}
-	return r.setConfigMapConfiguration(ctx, log, configmap, config)
+
+	saveRuleOwners(&configmap, owners)
+	if err := r.setConfigMapConfiguration(ctx, log, configmap, config); err != nil {
+		return err
+	}
+
+	syncFqdns := make([]string, 0, len(ownedFqdns))
+	for fqdn := range ownedFqdns {
+		syncFqdns = append(syncFqdns, fqdn)
+	}
+	degraded := r.syncDNS(ctx, log, ingress, configmap, syncFqdns, cleanup)
+
+	if !cleanup {
+		r.event(ingress, corev1.EventTypeNormal, "ConfigUpdated", "updated tunnel ingress rules for %v", fqdns)
+		r.reportIngressStatus(ctx, log, ingress, fqdns, conflict)
+		r.reportTunnelStatus(ctx, log, configmap, conflict, degraded)
+	}
+	return nil
+}
+
+// hostPathKey identifies a single tunnel ingress rule by the (hostname, path)
+// tuple it routes, which is the granularity cleanup removes at.
+type hostPathKey struct {
+	hostname string
+	path     string
+}
+
+// upsertIngressRule finds the (hostname, path) entry in config and updates its
+// Service and OriginRequest in place, or appends a new entry when it doesn't
+// exist yet. It is a no-op during cleanup, where removal is handled in bulk by
+// the caller.
+func (r *IngressReconciler) upsertIngressRule(log logr.Logger, config *Configuration, hostname, path, service string, origin OriginRequestConfig, cleanup bool) {
+	if cleanup {
+		return
+	}
+	for i, v := range config.Ingress {
+		if v.Hostname == hostname && v.Path == path {
+			log.Info("found existing ingress for host/path, modifying the service", "hostname", hostname, "path", path, "service", service)
+			config.Ingress[i].Service = service
+			config.Ingress[i].OriginRequest = mergeOriginRequest(v.OriginRequest, origin)
+			return
+		}
+	}
+	log.Info("adding ingress for host/path to point to service", "hostname", hostname, "path", path, "service", service)
+	config.Ingress = append(config.Ingress, UnvalidatedIngressRule{
+		Hostname:      hostname,
+		Path:          path,
+		Service:       service,
+		OriginRequest: origin,
+	})
+}
+
+// parseOriginRequestAnnotations builds an OriginRequestConfig from the
+// `origin-<field>` annotation family, falling back to deriving
+// originServerName from the Ingress' TLS hosts when it isn't set explicitly.
+func (r *IngressReconciler) parseOriginRequestAnnotations(ingress *networkingv1.Ingress, host string) OriginRequestConfig {
+	var cfg OriginRequestConfig
+
+	annotation := func(field string) (string, bool) {
+		v, ok := ingress.Annotations[originRequestAnnotationPrefix+field]
+		return v, ok
+	}
+
+	if v, ok := annotation("noTLSVerify"); ok {
+		noTLSVerify := v == "true"
+		cfg.NoTLSVerify = &noTLSVerify
+	}
+	if v, ok := annotation("httpHostHeader"); ok {
+		cfg.HTTPHostHeader = &v
+	}
+	if v, ok := annotation("originServerName"); ok {
+		cfg.OriginServerName = &v
+	} else if hasTLSForHost(ingress, host) {
+		sni := host
+		cfg.OriginServerName = &sni
+	}
+	if v, ok := annotation("connectTimeout"); ok {
+		cfg.ConnectTimeout = &v
+	}
+	if v, ok := annotation("tlsTimeout"); ok {
+		cfg.TLSTimeout = &v
+	}
+	if v, ok := annotation("keepAliveConnections"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.KeepAliveConnections = &n
+		}
+	}
+	if v, ok := annotation("caPoolSecret"); ok {
+		caPool := fmt.Sprintf("%s/%s/ca.crt", caPoolMountPath, v)
+		cfg.CAPool = &caPool
+	}
+	if v, ok := annotation("proxyType"); ok {
+		cfg.ProxyType = &v
+	}
+	if v, ok := annotation("accessTeamName"); ok {
+		cfg.Access = withAccess(cfg.Access, func(a *AccessConfig) { a.TeamName = v })
+	}
+	if v, ok := annotation("accessAudTag"); ok {
+		cfg.Access = withAccess(cfg.Access, func(a *AccessConfig) { a.AudTag = strings.Split(v, ",") })
+	}
+	if v, ok := annotation("access"); ok {
+		cfg.Access = withAccess(cfg.Access, func(a *AccessConfig) { a.Required = v == "true" })
+	}
+
+	return cfg
+}
+
+func withAccess(access *AccessConfig, set func(*AccessConfig)) *AccessConfig {
+	if access == nil {
+		access = &AccessConfig{}
+	}
+	set(access)
+	return access
+}
+
+// hasTLSForHost reports whether the Ingress has a TLS block covering host, so
+// its SNI can be used as the originServerName default.
+func hasTLSForHost(ingress *networkingv1.Ingress, host string) bool {
+	for _, tls := range ingress.Spec.TLS {
+		for _, h := range tls.Hosts {
+			if h == host {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mergeOriginRequest overlays the non-nil fields of override onto base, so a
+// per-Ingress annotation only overrides the fields it explicitly sets and
+// leaves any Tunnel-CR-level defaults already present on the entry alone.
+func mergeOriginRequest(base, override OriginRequestConfig) OriginRequestConfig {
+	merged := base
+	if override.NoTLSVerify != nil {
+		merged.NoTLSVerify = override.NoTLSVerify
+	}
+	if override.HTTPHostHeader != nil {
+		merged.HTTPHostHeader = override.HTTPHostHeader
+	}
+	if override.OriginServerName != nil {
+		merged.OriginServerName = override.OriginServerName
+	}
+	if override.ConnectTimeout != nil {
+		merged.ConnectTimeout = override.ConnectTimeout
+	}
+	if override.TLSTimeout != nil {
+		merged.TLSTimeout = override.TLSTimeout
+	}
+	if override.KeepAliveConnections != nil {
+		merged.KeepAliveConnections = override.KeepAliveConnections
+	}
+	if override.CAPool != nil {
+		merged.CAPool = override.CAPool
+	}
+	if override.ProxyType != nil {
+		merged.ProxyType = override.ProxyType
+	}
+	if override.Access != nil {
+		merged.Access = override.Access
+	}
+	return merged
+}
+
+// cloudflarePath converts an Ingress HTTPIngressPath into the path regex
+// cloudflared expects in its ingress rules.
+func cloudflarePath(path networkingv1.HTTPIngressPath) string {
+	pathType := networkingv1.PathTypeImplementationSpecific
+	if path.PathType != nil {
+		pathType = *path.PathType
+	}
+	switch pathType {
+	case networkingv1.PathTypeExact:
+		return fmt.Sprintf("^%s$", path.Path)
+	case networkingv1.PathTypePrefix:
+		return fmt.Sprintf("%s(/.*)?", strings.TrimSuffix(path.Path, "/"))
+	default:
+		return path.Path
+	}
+}
+
+// sortPathsBySpecificity orders paths so Exact rules come first, then Prefix
+// rules from longest to shortest, then ImplementationSpecific rules - matching
+// the order cloudflared needs to evaluate the more specific rule first.
+func sortPathsBySpecificity(paths []networkingv1.HTTPIngressPath) []networkingv1.HTTPIngressPath {
+	sorted := make([]networkingv1.HTTPIngressPath, len(paths))
+	copy(sorted, paths)
+	specificity := func(path networkingv1.HTTPIngressPath) int {
+		if path.PathType == nil {
+			return 2
+		}
+		switch *path.PathType {
+		case networkingv1.PathTypeExact:
+			return 0
+		case networkingv1.PathTypePrefix:
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		si, sj := specificity(sorted[i]), specificity(sorted[j])
+		if si != sj {
+			return si < sj
+		}
+		return len(sorted[i].Path) > len(sorted[j].Path)
+	})
+	return sorted
+}
+
+// cloudflarePathSpecificity ranks an already-converted cloudflared path string
+// the same way sortPathsBySpecificity ranks HTTPIngressPaths, so entries
+// accumulated across separate reconciles can still be ordered consistently:
+// Exact (0) before Prefix (1) before ImplementationSpecific (2) before the
+// host catch-all (3, empty path), longest path first within a rank.
+func cloudflarePathSpecificity(path string) (rank int, length int) {
+	switch {
+	case path == "":
+		return 3, 0
+	case strings.HasPrefix(path, "^") && strings.HasSuffix(path, "$"):
+		return 0, len(path)
+	case strings.HasSuffix(path, "(/.*)?"):
+		return 1, len(path)
+	default:
+		return 2, len(path)
+	}
+}
+
+// resortHostRules re-orders the config.Ingress entries for hostname by
+// specificity, in place, leaving every other hostname's entries at their
+// original positions. sortPathsBySpecificity only orders a single reconcile's
+// batch of paths; rules for a host accumulate across reconciles (e.g. a more
+// specific path added later than an existing catch-all), so the accumulated
+// set needs re-sorting every time to keep cloudflared's top-down evaluation
+// order correct.
+func resortHostRules(config *Configuration, hostname string) {
+	var indexes []int
+	var rules []UnvalidatedIngressRule
+	for i, v := range config.Ingress {
+		if v.Hostname != hostname {
+			continue
+		}
+		indexes = append(indexes, i)
+		rules = append(rules, v)
+	}
+	if len(rules) < 2 {
+		return
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		ri, li := cloudflarePathSpecificity(rules[i].Path)
+		rj, lj := cloudflarePathSpecificity(rules[j].Path)
+		if ri != rj {
+			return ri < rj
+		}
+		return li > lj
+	})
+	for n, i := range indexes {
+		config.Ingress[i] = rules[n]
+	}
+}
+
+// resolveBackendService resolves an Ingress path's backend Service name/port
+// to the cloudflared origin Service URL to proxy to.
+func (r *IngressReconciler) resolveBackendService(ctx context.Context, namespace string, backend *networkingv1.IngressServiceBackend) (string, error) {
+	if backend == nil {
+		return "", fmt.Errorf("backend has no Service (resource backends are not supported)")
+	}
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: backend.Name}, svc); err != nil {
+		return "", err
+	}
+	for _, port := range svc.Spec.Ports {
+		if backend.Port.Name != "" {
+			if port.Name == backend.Port.Name {
+				return fmt.Sprintf("http://%s.%s.svc:%d", backend.Name, namespace, port.Port), nil
+			}
+			continue
+		}
+		if port.Port == backend.Port.Number {
+			return fmt.Sprintf("http://%s.%s.svc:%d", backend.Name, namespace, port.Port), nil
+		}
+	}
+	return "", fmt.Errorf("service %s/%s has no port matching %+v", namespace, backend.Name, backend.Port)
+}
+
+// isTunnelConfigMap reports whether a ConfigMap looks like one this
+// controller manages, so the watch below doesn't do a full Ingress list for
+// every unrelated ConfigMap in the cluster (e.g. the kube-root-ca.crt
+// ConfigMap Kubernetes creates in every namespace).
+func isTunnelConfigMap(obj client.Object) bool {
+	if configmap, ok := obj.(*corev1.ConfigMap); ok {
+		if _, ok := configmap.Data[configmapKey]; ok {
+			return true
+		}
+	}
+	labels := obj.GetLabels()
+	_, hasCRD := labels[tunnelCRDAnnotation]
+	_, hasName := labels[tunnelNameAnnotation]
+	_, hasId := labels[tunnelIdAnnotation]
+	return hasCRD || hasName || hasId
+}
+
+// findIngressesForConfigMap re-enqueues every Ingress pointed at a ConfigMap
+// that changed out from under the controller (hand-edited, deleted and
+// recreated, replaced). Updates we wrote ourselves are recognized via the
+// content-hash annotation and skipped to avoid an infinite requeue loop.
+func (r *IngressReconciler) findIngressesForConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrllog.FromContext(ctx)
+
+	configmap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return nil
+	}
+	if configmap.Annotations[configMapContentHashAnnotation] == contentHash(configmap.Data[configmapKey]) {
+		return nil
+	}
+
+	ingressList := &networkingv1.IngressList{}
+	if err := r.List(ctx, ingressList); err != nil {
+		log.Error(err, "unable to list Ingresses to re-sync for ConfigMap change", "configmap", client.ObjectKeyFromObject(configmap))
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		tunnelCRD, okCRD, tunnelName, okName, tunnelId, okId, tunnelNS, okNS, err := r.resolveTunnelParams(ctx, log, ingress)
+		if err != nil || !(okCRD || okName || okId) {
+			continue
+		}
+		labels, _ := tunnelSelector(ingress, tunnelCRD, okCRD, tunnelName, okName, tunnelId, okId, tunnelNS, okNS)
+		if labelsSubset(labels, configmap.Labels) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(ingress)})
+		}
+	}
+	return requests
+}
+
+// findIngressesForTunnel re-enqueues every Ingress whose resolved Tunnel CRD
+// selection points at the Tunnel that just changed.
+func (r *IngressReconciler) findIngressesForTunnel(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrllog.FromContext(ctx)
+
+	tunnel, ok := obj.(*tunnelsv1alpha1.Tunnel)
+	if !ok {
+		return nil
+	}
+
+	ingressList := &networkingv1.IngressList{}
+	if err := r.List(ctx, ingressList); err != nil {
+		log.Error(err, "unable to list Ingresses to re-sync for Tunnel change", "tunnel", client.ObjectKeyFromObject(tunnel))
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		tunnelCRD, okCRD, _, _, _, _, tunnelNS, okNS, err := r.resolveTunnelParams(ctx, log, ingress)
+		if err != nil || !okCRD || tunnelCRD != tunnel.Name {
+			continue
+		}
+		if namespace, restricted := resolveTunnelNamespace(ingress, tunnelNS, okNS); restricted && namespace != tunnel.Namespace {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(ingress)})
+	}
+	return requests
+}
+
+// findIngressesForIngressClass re-enqueues every Ingress that references an
+// IngressClass that just changed, so a change to its parameters (or
+// controller value) is picked up without waiting for an unrelated Ingress
+// event.
+func (r *IngressReconciler) findIngressesForIngressClass(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrllog.FromContext(ctx)
+
+	ingressClass, ok := obj.(*networkingv1.IngressClass)
+	if !ok {
+		return nil
+	}
+
+	ingressList := &networkingv1.IngressList{}
+	if err := r.List(ctx, ingressList); err != nil {
+		log.Error(err, "unable to list Ingresses to re-sync for IngressClass change", "ingressClass", ingressClass.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if ingress.Spec.IngressClassName == nil || *ingress.Spec.IngressClassName != ingressClass.Name {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(ingress)})
+	}
+	return requests
+}
+
+// event records a Kubernetes Event on the Ingress if a Recorder was injected,
+// and is a no-op otherwise so the reconciler keeps working without one.
+func (r *IngressReconciler) event(ingress *networkingv1.Ingress, eventType, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(ingress, eventType, reason, messageFmt, args...)
+}
+
+// notifyOwner records the same conflict Event on the Ingress that owns the
+// contested rule, identified by its "namespace/name" ref, so a hostname
+// conflict is surfaced on both sides instead of only on the Ingress that
+// lost the write this reconcile.
+func (r *IngressReconciler) notifyOwner(ctx context.Context, log logr.Logger, ownerRef string, eventType, reason, messageFmt string, args ...interface{}) {
+	namespace, name, found := strings.Cut(ownerRef, "/")
+	if !found {
+		return
+	}
+	owner := &networkingv1.Ingress{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, owner); err != nil {
+		log.Error(err, "unable to fetch owning Ingress to surface hostname conflict", "owner", ownerRef)
+		return
+	}
+	r.event(owner, eventType, reason, messageFmt, args...)
+}
+
+// reportIngressStatus mirrors the FQDNs this reconcile resolved onto the
+// Ingress' LoadBalancer status, which is the only status field the built-in
+// Ingress type exposes.
+func (r *IngressReconciler) reportIngressStatus(ctx context.Context, log logr.Logger, ingress *networkingv1.Ingress, fqdns []string, conflict bool) {
+	if conflict {
+		// Leave the previously reported, successfully configured hostnames in
+		// place rather than clearing them out because of the contested one.
+		return
+	}
+	lbIngress := make([]networkingv1.IngressLoadBalancerIngress, 0, len(fqdns))
+	for _, fqdn := range fqdns {
+		lbIngress = append(lbIngress, networkingv1.IngressLoadBalancerIngress{Hostname: fqdn})
+	}
+	ingress.Status.LoadBalancer.Ingress = lbIngress
+	if err := r.Status().Update(ctx, ingress); err != nil {
+		log.Error(err, "unable to update Ingress status")
+	}
+}
+
+// reportTunnelStatus sets a Ready/Degraded/Conflict condition on the Tunnel
+// CRD that serves this ConfigMap, so `kubectl get tunnel` reflects what's
+// actually configured instead of requiring the operator to read logs.
+// conflict takes precedence over degraded: a rule write that was refused
+// outright is a more specific signal than "the rules it did write may not be
+// reachable yet."
+func (r *IngressReconciler) reportTunnelStatus(ctx context.Context, log logr.Logger, configmap corev1.ConfigMap, conflict, degraded bool) {
+	tunnelName, ok := configmap.Labels[tunnelCRDAnnotation]
+	if !ok {
+		return
+	}
+	tunnel := &tunnelsv1alpha1.Tunnel{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: configmap.Namespace, Name: tunnelName}, tunnel); err != nil {
+		log.Error(err, "unable to fetch Tunnel to update its status", "tunnel", tunnelName)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:    "Ready",
+		Status:  metav1.ConditionTrue,
+		Reason:  "ConfigUpdated",
+		Message: "tunnel ingress rules configured",
+	}
+	switch {
+	case conflict:
+		condition = metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  "Conflict",
+			Message: "one or more Ingresses claim the same hostname; see Ingress events for details",
+		}
+	case degraded:
+		condition = metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  "Degraded",
+			Message: "tunnel ingress rules configured, but DNS sync failed for one or more hostnames; see Ingress events for details",
+		}
+	}
+	meta.SetStatusCondition(&tunnel.Status.Conditions, condition)
+
+	if err := r.Status().Update(ctx, tunnel); err != nil {
+		log.Error(err, "unable to update Tunnel status", "tunnel", tunnelName)
+	}
+}
+
+// syncDNS upserts (or, during cleanup, deletes) the proxied DNS record for
+// each fqdn this Ingress resolved, when the Ingress (or the controller-wide
+// ManageDNS flag) opts into DNS management. Failures are logged and surfaced
+// as Events rather than failing the whole reconcile, since the tunnel config
+// itself was already written successfully; degraded reports whether any
+// failure occurred, so the caller can reflect it in the Tunnel's condition.
+func (r *IngressReconciler) syncDNS(ctx context.Context, log logr.Logger, ingress *networkingv1.Ingress, configmap corev1.ConfigMap, fqdns []string, cleanup bool) (degraded bool) {
+	if !r.ManageDNS && ingress.Annotations[manageDNSAnnotation] != "true" {
+		return false
+	}
+
+	tunnelName, ok := configmap.Labels[tunnelCRDAnnotation]
+	if !ok {
+		return false
+	}
+	tunnel := &tunnelsv1alpha1.Tunnel{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: configmap.Namespace, Name: tunnelName}, tunnel); err != nil {
+		log.Error(err, "unable to fetch Tunnel for DNS management", "tunnel", tunnelName)
+		return false
+	}
+
+	dnsClient, err := r.dnsClientForTunnel(ctx, tunnel)
+	if err != nil {
+		log.Error(err, "unable to build Cloudflare client for DNS management", "tunnel", tunnelName)
+		r.event(ingress, corev1.EventTypeWarning, "DNSSyncFailed", "unable to build Cloudflare client: %v", err)
+		return true
+	}
+
+	recordType := dns.RecordTypeCNAME
+	if v, ok := ingress.Annotations[dnsRecordTypeAnnotation]; ok {
+		recordType = dns.RecordType(strings.ToUpper(v))
+	}
+
+	target := fmt.Sprintf("%s.cfargotunnel.com", tunnel.Status.TunnelId)
+	if recordType != dns.RecordTypeCNAME {
+		target = ingress.Annotations[dnsTargetAnnotation]
+		if target == "" {
+			log.Info("dns-record-type pins to an edge IP but dns-target annotation is not set, skipping DNS sync", "recordType", recordType)
+			return false
+		}
+	}
+
+	for _, fqdn := range fqdns {
+		var syncErr error
+		if cleanup {
+			syncErr = dnsClient.Delete(ctx, tunnel.Spec.ZoneID, fqdn, recordType)
+		} else {
+			syncErr = dnsClient.Upsert(ctx, tunnel.Spec.ZoneID, fqdn, recordType, target)
+		}
+		if syncErr != nil {
+			log.Error(syncErr, "unable to sync DNS record", "fqdn", fqdn, "cleanup", cleanup)
+			r.event(ingress, corev1.EventTypeWarning, "DNSSyncFailed", "unable to sync DNS record for %s: %v", fqdn, syncErr)
+			degraded = true
+		}
+	}
+	return degraded
+}
+
+// dnsClientForTunnel returns the injected DNSClient if set, otherwise builds
+// one from the API token in the Secret referenced on the Tunnel CR.
+func (r *IngressReconciler) dnsClientForTunnel(ctx context.Context, tunnel *tunnelsv1alpha1.Tunnel) (dns.Client, error) {
+	if r.DNSClient != nil {
+		return r.DNSClient, nil
+	}
+
+	if tunnel.Spec.Cloudflare.Secret == "" {
+		return nil, fmt.Errorf("tunnel %s/%s has no Cloudflare credentials Secret configured", tunnel.Namespace, tunnel.Name)
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: tunnel.Namespace, Name: tunnel.Spec.Cloudflare.Secret}, secret); err != nil {
+		return nil, err
+	}
+	apiToken := string(secret.Data[cloudflareAPITokenSecretKey])
+	if apiToken == "" {
+		return nil, fmt.Errorf("secret %s/%s has no %s key", tunnel.Namespace, tunnel.Spec.Cloudflare.Secret, cloudflareAPITokenSecretKey)
+	}
+
+	factory := r.DNSClientFactory
+	if factory == nil {
+		factory = func(apiToken string) (dns.Client, error) { return dns.NewCloudflareClient(apiToken) }
+	}
+	return factory(apiToken)
+}
+
+// labelsSubset reports whether every key/value in selector is present in actual.
+func labelsSubset(selector, actual map[string]string) bool {
+	for k, v := range selector {
+		if actual[k] != v {
+			return false
+		}
+	}
+	return true
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *IngressReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.ControllerClass == "" {
+		r.ControllerClass = defaultControllerClass
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&networkingv1.Ingress{}).
+		Watches(&networkingv1.IngressClass{}, handler.EnqueueRequestsFromMapFunc(r.findIngressesForIngressClass)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.findIngressesForConfigMap), builder.WithPredicates(predicate.NewPredicateFuncs(isTunnelConfigMap))).
+		Watches(&tunnelsv1alpha1.Tunnel{}, handler.EnqueueRequestsFromMapFunc(r.findIngressesForTunnel)).
 		Complete(r)
 }
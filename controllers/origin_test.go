@@ -0,0 +1,70 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import "testing"
+
+func strPtr(s string) *string { return &s }
+func boolPtr(b bool) *bool    { return &b }
+func intPtr(i int) *int       { return &i }
+
+func TestMergeOriginRequest(t *testing.T) {
+	base := OriginRequestConfig{
+		NoTLSVerify:      boolPtr(false),
+		HTTPHostHeader:   strPtr("base-host"),
+		OriginServerName: strPtr("base.example.com"),
+		ConnectTimeout:   strPtr("10s"),
+	}
+
+	t.Run("override leaves unset base fields alone", func(t *testing.T) {
+		override := OriginRequestConfig{HTTPHostHeader: strPtr("override-host")}
+
+		got := mergeOriginRequest(base, override)
+
+		if got.HTTPHostHeader == nil || *got.HTTPHostHeader != "override-host" {
+			t.Errorf("HTTPHostHeader = %v, want override-host", got.HTTPHostHeader)
+		}
+		if got.NoTLSVerify == nil || *got.NoTLSVerify != false {
+			t.Errorf("NoTLSVerify = %v, want base value preserved", got.NoTLSVerify)
+		}
+		if got.OriginServerName == nil || *got.OriginServerName != "base.example.com" {
+			t.Errorf("OriginServerName = %v, want base value preserved", got.OriginServerName)
+		}
+		if got.ConnectTimeout == nil || *got.ConnectTimeout != "10s" {
+			t.Errorf("ConnectTimeout = %v, want base value preserved", got.ConnectTimeout)
+		}
+	})
+
+	t.Run("empty override is a no-op", func(t *testing.T) {
+		got := mergeOriginRequest(base, OriginRequestConfig{})
+		if got != base {
+			t.Errorf("mergeOriginRequest with empty override = %+v, want %+v", got, base)
+		}
+	})
+
+	t.Run("override replaces Access wholesale", func(t *testing.T) {
+		withAccess := base
+		withAccess.Access = &AccessConfig{TeamName: "base-team"}
+		override := OriginRequestConfig{Access: &AccessConfig{TeamName: "override-team"}}
+
+		got := mergeOriginRequest(withAccess, override)
+
+		if got.Access == nil || got.Access.TeamName != "override-team" {
+			t.Errorf("Access = %+v, want override-team", got.Access)
+		}
+	})
+}
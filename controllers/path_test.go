@@ -0,0 +1,75 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func httpPath(path string, pathType networkingv1.PathType) networkingv1.HTTPIngressPath {
+	return networkingv1.HTTPIngressPath{Path: path, PathType: &pathType}
+}
+
+func TestSortPathsBySpecificity(t *testing.T) {
+	paths := []networkingv1.HTTPIngressPath{
+		httpPath("/foo", networkingv1.PathTypePrefix),
+		httpPath("/foo/bar", networkingv1.PathTypeExact),
+		httpPath("/baz", networkingv1.PathTypeImplementationSpecific),
+		httpPath("/foo/bar/baz", networkingv1.PathTypePrefix),
+	}
+
+	sorted := sortPathsBySpecificity(paths)
+
+	want := []string{"/foo/bar", "/foo/bar/baz", "/foo", "/baz"}
+	if len(sorted) != len(want) {
+		t.Fatalf("got %d paths, want %d", len(sorted), len(want))
+	}
+	for i, path := range sorted {
+		if path.Path != want[i] {
+			t.Errorf("position %d: got %q, want %q", i, path.Path, want[i])
+		}
+	}
+
+	// Original slice must be left untouched.
+	if paths[0].Path != "/foo" {
+		t.Errorf("sortPathsBySpecificity mutated its input slice")
+	}
+}
+
+func TestCloudflarePath(t *testing.T) {
+	tests := []struct {
+		name string
+		path networkingv1.HTTPIngressPath
+		want string
+	}{
+		{"exact", httpPath("/foo", networkingv1.PathTypeExact), "^/foo$"},
+		{"prefix", httpPath("/foo", networkingv1.PathTypePrefix), "/foo(/.*)?"},
+		{"prefix trailing slash trimmed", httpPath("/foo/", networkingv1.PathTypePrefix), "/foo(/.*)?"},
+		{"implementation specific passes through", httpPath("/foo.*", networkingv1.PathTypeImplementationSpecific), "/foo.*"},
+		{"nil path type defaults to passthrough", networkingv1.HTTPIngressPath{Path: "/foo"}, "/foo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cloudflarePath(tt.path); got != tt.want {
+				t.Errorf("cloudflarePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
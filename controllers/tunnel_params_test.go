@@ -0,0 +1,112 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newIngressClassScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := networkingv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to register networking/v1 scheme: %v", err)
+	}
+	return scheme
+}
+
+func TestResolveTunnelParamsControllerClassPrecedence(t *testing.T) {
+	className := "cloudflare-tunnel"
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			Annotations: map[string]string{
+				tunnelNameAnnotation: "legacy-tunnel",
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: &className,
+		},
+	}
+
+	t.Run("matching controller class resolves from IngressClass params", func(t *testing.T) {
+		ingressClass := &networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: className},
+			Spec: networkingv1.IngressClassSpec{
+				Controller: defaultControllerClass,
+				Parameters: &networkingv1.IngressClassParametersReference{
+					Kind: ingressClassParamCRDKind,
+					Name: "crd-tunnel",
+				},
+			},
+		}
+		r := &IngressReconciler{
+			Client: fake.NewClientBuilder().WithScheme(newIngressClassScheme(t)).WithObjects(ingressClass).Build(),
+		}
+
+		tunnelCRD, okCRD, _, _, _, _, _, _, err := r.resolveTunnelParams(context.Background(), logr.Discard(), ingress)
+		if err != nil {
+			t.Fatalf("resolveTunnelParams() error = %v", err)
+		}
+		if !okCRD || tunnelCRD != "crd-tunnel" {
+			t.Errorf("got tunnelCRD=%q okCRD=%v, want crd-tunnel/true", tunnelCRD, okCRD)
+		}
+	})
+
+	t.Run("mismatched controller class discards legacy annotations entirely", func(t *testing.T) {
+		ingressClass := &networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: className},
+			Spec: networkingv1.IngressClassSpec{
+				Controller: "some-other-operator.example.com/controller",
+			},
+		}
+		r := &IngressReconciler{
+			Client: fake.NewClientBuilder().WithScheme(newIngressClassScheme(t)).WithObjects(ingressClass).Build(),
+		}
+
+		tunnelCRD, okCRD, tunnelName, okName, tunnelId, okId, tunnelNS, okNS, err := r.resolveTunnelParams(context.Background(), logr.Discard(), ingress)
+		if err != nil {
+			t.Fatalf("resolveTunnelParams() error = %v", err)
+		}
+		if okCRD || okName || okId || okNS || tunnelCRD != "" || tunnelName != "" || tunnelId != "" || tunnelNS != "" {
+			t.Errorf("expected every value discarded for a foreign IngressClass, got tunnelCRD=%q okCRD=%v tunnelName=%q okName=%v tunnelId=%q okId=%v tunnelNS=%q okNS=%v",
+				tunnelCRD, okCRD, tunnelName, okName, tunnelId, okId, tunnelNS, okNS)
+		}
+	})
+
+	t.Run("no IngressClassName falls back to legacy annotations", func(t *testing.T) {
+		r := &IngressReconciler{Client: fake.NewClientBuilder().WithScheme(newIngressClassScheme(t)).Build()}
+		noClass := ingress.DeepCopy()
+		noClass.Spec.IngressClassName = nil
+
+		_, _, tunnelName, okName, _, _, _, _, err := r.resolveTunnelParams(context.Background(), logr.Discard(), noClass)
+		if err != nil {
+			t.Fatalf("resolveTunnelParams() error = %v", err)
+		}
+		if !okName || tunnelName != "legacy-tunnel" {
+			t.Errorf("got tunnelName=%q okName=%v, want legacy-tunnel/true", tunnelName, okName)
+		}
+	})
+}
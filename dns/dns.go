@@ -0,0 +1,110 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dns manages the DNS records that route traffic at the Cloudflare
+// edge to a tunnel, mirroring the ingress rules IngressReconciler writes to
+// the cloudflared ConfigMap.
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	cfgo "github.com/cloudflare/cloudflare-go"
+)
+
+// RecordType is a DNS record type this package knows how to manage.
+type RecordType string
+
+const (
+	RecordTypeCNAME RecordType = "CNAME"
+	RecordTypeA     RecordType = "A"
+	RecordTypeAAAA  RecordType = "AAAA"
+)
+
+// Client upserts and removes the proxied DNS record for a single FQDN. It is
+// satisfied by CloudflareClient in production and can be faked in tests.
+type Client interface {
+	// Upsert creates or updates a proxied DNS record of the given type for
+	// fqdn in zoneID, pointing it at target (a tunnel CNAME target like
+	// "<uuid>.cfargotunnel.com", or a pinned edge IP for A/AAAA).
+	Upsert(ctx context.Context, zoneID, fqdn string, recordType RecordType, target string) error
+	// Delete removes the DNS record of the given type for fqdn in zoneID, if
+	// one exists, leaving any other record type at the same fqdn alone.
+	Delete(ctx context.Context, zoneID, fqdn string, recordType RecordType) error
+}
+
+// CloudflareClient is a Client backed by the Cloudflare API.
+type CloudflareClient struct {
+	api *cfgo.API
+}
+
+// NewCloudflareClient builds a Client authenticated with an API token, as
+// read from the Secret referenced on the Tunnel CR.
+func NewCloudflareClient(apiToken string) (*CloudflareClient, error) {
+	api, err := cfgo.NewWithAPIToken(apiToken)
+	if err != nil {
+		return nil, fmt.Errorf("building Cloudflare API client: %w", err)
+	}
+	return &CloudflareClient{api: api}, nil
+}
+
+func (c *CloudflareClient) Upsert(ctx context.Context, zoneID, fqdn string, recordType RecordType, target string) error {
+	rc := cfgo.ZoneIdentifier(zoneID)
+	existing, _, err := c.api.ListDNSRecords(ctx, rc, cfgo.ListDNSRecordsParams{Name: fqdn, Type: string(recordType)})
+	if err != nil {
+		return fmt.Errorf("listing DNS records for %s: %w", fqdn, err)
+	}
+
+	proxied := true
+	if len(existing) > 0 {
+		_, err := c.api.UpdateDNSRecord(ctx, rc, cfgo.UpdateDNSRecordParams{
+			ID:      existing[0].ID,
+			Type:    string(recordType),
+			Name:    fqdn,
+			Content: target,
+			Proxied: &proxied,
+		})
+		if err != nil {
+			return fmt.Errorf("updating DNS record for %s: %w", fqdn, err)
+		}
+		return nil
+	}
+
+	if _, err := c.api.CreateDNSRecord(ctx, rc, cfgo.CreateDNSRecordParams{
+		Type:    string(recordType),
+		Name:    fqdn,
+		Content: target,
+		Proxied: &proxied,
+	}); err != nil {
+		return fmt.Errorf("creating DNS record for %s: %w", fqdn, err)
+	}
+	return nil
+}
+
+func (c *CloudflareClient) Delete(ctx context.Context, zoneID, fqdn string, recordType RecordType) error {
+	rc := cfgo.ZoneIdentifier(zoneID)
+	existing, _, err := c.api.ListDNSRecords(ctx, rc, cfgo.ListDNSRecordsParams{Name: fqdn, Type: string(recordType)})
+	if err != nil {
+		return fmt.Errorf("listing DNS records for %s: %w", fqdn, err)
+	}
+	for _, record := range existing {
+		if err := c.api.DeleteDNSRecord(ctx, rc, record.ID); err != nil {
+			return fmt.Errorf("deleting DNS record for %s: %w", fqdn, err)
+		}
+	}
+	return nil
+}